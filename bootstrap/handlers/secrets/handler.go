@@ -0,0 +1,261 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package secrets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/secret"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	"github.com/gorilla/mux"
+)
+
+const (
+	// ApiSecretRoute is the route for operating on a single named secret.
+	ApiSecretRoute = "/api/v3/secret/{name}"
+	// ApiSecretCollectionRoute is the route for creating a secret and listing secret names.
+	ApiSecretCollectionRoute = "/api/v3/secret"
+
+	operatorRole = "operator"
+)
+
+// secretRequest is the body accepted by the create/update endpoints. SecretName is required for
+// create (the collection route has no {name} path segment) and ignored for update, which takes
+// the name from the path.
+type secretRequest struct {
+	SecretName string                      `json:"secretName"`
+	SecretData []common.SecretDataKeyValue `json:"secretData"`
+}
+
+// secretResponse is returned by the get endpoint.
+type secretResponse struct {
+	SecretName string                      `json:"secretName"`
+	SecretData []common.SecretDataKeyValue `json:"secretData"`
+}
+
+// secretNamesResponse is returned by the list endpoint.
+type secretNamesResponse struct {
+	SecretNames []string `json:"secretNames"`
+}
+
+// errorResponse is returned for every non-2xx response.
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+// Handler implements the HTTP admin API for secret CRUD, authorizing every request against
+// SecureProvider's JWKS-based JWT verifier.
+type Handler struct {
+	provider   SecretProvider
+	serviceKey string
+	issuer     string
+	lc         logger.LoggingClient
+}
+
+// NewHandler creates a Handler that authorizes requests as belonging either to serviceKey's own
+// identity or to a JWT carrying the operator role. Every token must additionally have been issued
+// by issuer for serviceKey's audience, so a token valid for some other service on the same JWKS
+// issuer is rejected.
+func NewHandler(provider SecretProvider, serviceKey string, issuer string, lc logger.LoggingClient) *Handler {
+	return &Handler{provider: provider, serviceKey: serviceKey, issuer: issuer, lc: lc}
+}
+
+// AddRoutes registers the secret CRUD endpoints on router.
+func (h *Handler) AddRoutes(router *mux.Router) {
+	router.HandleFunc(ApiSecretCollectionRoute, h.create).Methods(http.MethodPost)
+	router.HandleFunc(ApiSecretCollectionRoute, h.list).Methods(http.MethodGet)
+	router.HandleFunc(ApiSecretRoute, h.get).Methods(http.MethodGet)
+	router.HandleFunc(ApiSecretRoute, h.update).Methods(http.MethodPut)
+	router.HandleFunc(ApiSecretRoute, h.delete).Methods(http.MethodDelete)
+}
+
+func (h *Handler) create(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	var request secretRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	if err := validateSecretName(request.SecretName); err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.store(w, request.SecretName, request.SecretData, http.StatusCreated)
+}
+
+func (h *Handler) update(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	secretName := mux.Vars(r)["name"]
+	if err := validateSecretName(secretName); err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var request secretRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	h.store(w, secretName, request.SecretData, http.StatusOK)
+}
+
+func (h *Handler) store(w http.ResponseWriter, secretName string, secretData []common.SecretDataKeyValue, successStatus int) {
+	secretsKV := make(map[string]string, len(secretData))
+	for _, entry := range secretData {
+		secretsKV[entry.Key] = entry.Value
+	}
+
+	if err := h.provider.StoreSecret(secretName, secretsKV); err != nil {
+		h.writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.WriteHeader(successStatus)
+}
+
+// validateSecretName rejects names that are empty or could be used to reach outside a
+// backend's intended storage scope (e.g. "../" path traversal segments).
+func validateSecretName(secretName string) error {
+	if secretName == "" {
+		return errors.New("secretName is required")
+	}
+
+	if strings.Contains(secretName, "..") {
+		return fmt.Errorf("invalid secretName '%s'", secretName)
+	}
+
+	return nil
+}
+
+func (h *Handler) get(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	secretName := mux.Vars(r)["name"]
+	if err := validateSecretName(secretName); err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	secretsKV, err := h.provider.GetSecret(secretName)
+	if err != nil {
+		h.writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	secretData := make([]common.SecretDataKeyValue, 0, len(secretsKV))
+	for key, value := range secretsKV {
+		secretData = append(secretData, common.SecretDataKeyValue{Key: key, Value: value})
+	}
+
+	h.writeJSON(w, http.StatusOK, secretResponse{SecretName: secretName, SecretData: secretData})
+}
+
+func (h *Handler) list(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	names, err := h.provider.ListSecretNames()
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, secretNamesResponse{SecretNames: names})
+}
+
+func (h *Handler) delete(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	secretName := mux.Vars(r)["name"]
+	if err := validateSecretName(secretName); err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.provider.DeleteSecret(secretName); err != nil {
+		h.writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authorize requires a valid bearer JWT whose "sub" claim matches h.serviceKey or whose "role"
+// claim is the operator role. It writes the error response itself and returns false on failure.
+func (h *Handler) authorize(w http.ResponseWriter, r *http.Request) bool {
+	token, err := bearerToken(r)
+	if err != nil {
+		h.writeError(w, http.StatusUnauthorized, err.Error())
+		return false
+	}
+
+	claims, err := h.provider.VerifyJWT(token, secret.VerifyOptions{Issuer: h.issuer, Audience: h.serviceKey})
+	if err != nil {
+		h.lc.Debugf("rejecting secret API request: %v", err)
+		h.writeError(w, http.StatusUnauthorized, "invalid or expired token")
+		return false
+	}
+
+	if subject, ok := claims.StringClaim("sub"); ok && subject == h.serviceKey {
+		return true
+	}
+
+	if role, ok := claims.StringClaim("role"); ok && role == operatorRole {
+		return true
+	}
+
+	h.writeError(w, http.StatusForbidden, "token is not authorized to manage this service's secrets")
+	return false
+}
+
+func bearerToken(r *http.Request) (string, error) {
+	header := r.Header.Get("Authorization")
+	const prefix = "Bearer "
+	if !strings.HasPrefix(header, prefix) {
+		return "", errors.New("missing bearer token")
+	}
+	return strings.TrimPrefix(header, prefix), nil
+}
+
+func (h *Handler) writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		h.lc.Errorf("failed to encode secret API response: %v", err)
+	}
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, statusCode int, message string) {
+	h.writeJSON(w, statusCode, errorResponse{Message: message})
+}
@@ -0,0 +1,206 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package secrets
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/secret"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testServiceKey = "device-service"
+
+// stubProvider is a minimal SecretProvider double for exercising the handlers without a real
+// SecureProvider/secret store.
+type stubProvider struct {
+	secrets       map[string]map[string]string
+	tokenClaims   map[string]secret.Claims
+	deleteErr     error
+	lastVerifyOpt secret.VerifyOptions
+}
+
+func newStubProvider() *stubProvider {
+	return &stubProvider{
+		secrets:     make(map[string]map[string]string),
+		tokenClaims: make(map[string]secret.Claims),
+	}
+}
+
+func (s *stubProvider) GetSecret(secretName string, _ ...string) (map[string]string, error) {
+	data, ok := s.secrets[secretName]
+	if !ok {
+		return nil, errors.New("secret not found")
+	}
+	return data, nil
+}
+
+func (s *stubProvider) StoreSecret(secretName string, secrets map[string]string) error {
+	s.secrets[secretName] = secrets
+	return nil
+}
+
+func (s *stubProvider) DeleteSecret(secretName string) error {
+	if s.deleteErr != nil {
+		return s.deleteErr
+	}
+	delete(s.secrets, secretName)
+	return nil
+}
+
+func (s *stubProvider) ListSecretNames() ([]string, error) {
+	names := make([]string, 0, len(s.secrets))
+	for name := range s.secrets {
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func (s *stubProvider) VerifyJWT(token string, opts secret.VerifyOptions) (secret.Claims, error) {
+	s.lastVerifyOpt = opts
+	claims, ok := s.tokenClaims[token]
+	if !ok {
+		return nil, errors.New("invalid token")
+	}
+	return claims, nil
+}
+
+// The remaining methods below are unused by this package's handlers but are required to satisfy
+// the shared interfaces.SecretProvider interface.
+
+func (s *stubProvider) SecretsUpdated() {}
+
+func (s *stubProvider) SecretsLastUpdated() time.Time {
+	return time.Time{}
+}
+
+func (s *stubProvider) GetAccessToken(_ string, _ string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (s *stubProvider) HasSecret(secretName string) (bool, error) {
+	_, ok := s.secrets[secretName]
+	return ok, nil
+}
+
+func (s *stubProvider) RegisteredSecretUpdatedCallback(_ string, _ func(secretName string)) error {
+	return nil
+}
+
+func (s *stubProvider) DeregisterSecretUpdatedCallback(_ string) {}
+
+func (s *stubProvider) GetMetricsToRegister() map[string]interface{} {
+	return nil
+}
+
+func (s *stubProvider) GetSelfJWT() (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (s *stubProvider) IsJWTValid(_ string) (bool, error) {
+	return false, errors.New("not implemented")
+}
+
+func newTestRouter(provider *stubProvider) *mux.Router {
+	handler := NewHandler(provider, testServiceKey, "edgex", logger.NewMockClient())
+	router := mux.NewRouter()
+	handler.AddRoutes(router)
+	return router
+}
+
+func TestHandler_CreateRequiresAuthorization(t *testing.T) {
+	provider := newStubProvider()
+	router := newTestRouter(provider)
+
+	req := httptest.NewRequest(http.MethodPost, ApiSecretCollectionRoute, bytes.NewBufferString(`{"secretName":"db"}`))
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
+
+func TestHandler_CreateAcceptsOwnServiceToken(t *testing.T) {
+	provider := newStubProvider()
+	provider.tokenClaims["own-token"] = secret.Claims{"sub": testServiceKey}
+	router := newTestRouter(provider)
+
+	body := `{"secretName":"db","secretData":[{"key":"username","value":"alice"}]}`
+	req := httptest.NewRequest(http.MethodPost, ApiSecretCollectionRoute, bytes.NewBufferString(body))
+	req.Header.Set("Authorization", "Bearer own-token")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	require.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Equal(t, "alice", provider.secrets["db"]["username"])
+	assert.Equal(t, "edgex", provider.lastVerifyOpt.Issuer)
+	assert.Equal(t, testServiceKey, provider.lastVerifyOpt.Audience)
+}
+
+func TestHandler_CreateRejectsUnrelatedServiceToken(t *testing.T) {
+	provider := newStubProvider()
+	provider.tokenClaims["other-token"] = secret.Claims{"sub": "some-other-service"}
+	router := newTestRouter(provider)
+
+	body := `{"secretName":"db","secretData":[{"key":"username","value":"alice"}]}`
+	req := httptest.NewRequest(http.MethodPost, ApiSecretCollectionRoute, bytes.NewBufferString(body))
+	req.Header.Set("Authorization", "Bearer other-token")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+}
+
+func TestHandler_CreateRejectsPathTraversalSecretName(t *testing.T) {
+	provider := newStubProvider()
+	provider.tokenClaims["own-token"] = secret.Claims{"sub": testServiceKey}
+	router := newTestRouter(provider)
+
+	body := `{"secretName":"../../etc/cron.d/evil","secretData":[{"key":"k","value":"v"}]}`
+	req := httptest.NewRequest(http.MethodPost, ApiSecretCollectionRoute, bytes.NewBufferString(body))
+	req.Header.Set("Authorization", "Bearer own-token")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Empty(t, provider.secrets)
+}
+
+func TestHandler_GetAndDelete(t *testing.T) {
+	provider := newStubProvider()
+	provider.secrets["db"] = map[string]string{"username": "alice"}
+	provider.tokenClaims["operator-token"] = secret.Claims{"role": "operator"}
+	router := newTestRouter(provider)
+
+	getReq := httptest.NewRequest(http.MethodGet, "/api/v3/secret/db", nil)
+	getReq.Header.Set("Authorization", "Bearer operator-token")
+	getRecorder := httptest.NewRecorder()
+	router.ServeHTTP(getRecorder, getReq)
+	require.Equal(t, http.StatusOK, getRecorder.Code)
+
+	deleteReq := httptest.NewRequest(http.MethodDelete, "/api/v3/secret/db", nil)
+	deleteReq.Header.Set("Authorization", "Bearer operator-token")
+	deleteRecorder := httptest.NewRecorder()
+	router.ServeHTTP(deleteRecorder, deleteReq)
+	assert.Equal(t, http.StatusNoContent, deleteRecorder.Code)
+	assert.NotContains(t, provider.secrets, "db")
+}
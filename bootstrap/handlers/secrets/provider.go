@@ -0,0 +1,25 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+// Package secrets exposes a REST API for managing a service's own secrets, backed by
+// bootstrap/secret.SecureProvider.
+package secrets
+
+import "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
+
+// SecretProvider is the module's shared secret provider contract, implemented by
+// bootstrap/secret.SecureProvider. It is aliased here, rather than redeclared, so tests can
+// substitute a stub without pulling in a full secret store client while still satisfying the
+// same interface every other consumer of the secret provider depends on.
+type SecretProvider = interfaces.SecretProvider
@@ -0,0 +1,66 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+// Package interfaces holds the interfaces shared across go-mod-bootstrap and the services that
+// depend on it, so those services can depend on an interface rather than bootstrap/secret's
+// concrete types.
+package interfaces
+
+import (
+	"time"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/secret"
+)
+
+// SecretProvider defines the contract a service uses to retrieve, store and manage its secrets,
+// implemented by secret.SecureProvider (and any insecure equivalent).
+type SecretProvider interface {
+	// GetSecret retrieves secrets from a secret store.
+	// secretName specifies the type or location of the secrets to retrieve.
+	// keys specifies the secrets which to retrieve. If no keys are provided then all the keys
+	// associated with the specified secretName will be returned.
+	GetSecret(secretName string, keys ...string) (map[string]string, error)
+	// StoreSecret stores the secrets, it sets the values requested at provided keys.
+	// secretName specifies the type or location of the secrets to store.
+	// secrets map specifies the "key": "value" pairs of secrets to store.
+	StoreSecret(secretName string, secrets map[string]string) error
+	// DeleteSecret removes the secret stored at secretName.
+	DeleteSecret(secretName string) error
+	// SecretsUpdated informs the secret provider that an insecure secret has been updated.
+	SecretsUpdated()
+	// SecretsLastUpdated returns the last time secrets were updated.
+	SecretsLastUpdated() time.Time
+	// GetAccessToken returns the access token for the requested token type.
+	GetAccessToken(tokenType string, serviceKey string) (string, error)
+	// HasSecret returns true if the service's SecretStore contains a secret at the specified secretName.
+	HasSecret(secretName string) (bool, error)
+	// ListSecretNames returns a list of secretNames for the current service from an insecure/secure secret store.
+	ListSecretNames() ([]string, error)
+	// RegisteredSecretUpdatedCallback registers a callback for a secretName.
+	RegisteredSecretUpdatedCallback(secretName string, callback func(secretName string)) error
+	// DeregisterSecretUpdatedCallback removes a secret's registered callback for secretName.
+	DeregisterSecretUpdatedCallback(secretName string)
+	// GetMetricsToRegister returns all metric objects that needs to be registered.
+	GetMetricsToRegister() map[string]interface{}
+	// GetSelfJWT returns an encoded JWT for the current identity-based secret store token.
+	GetSelfJWT() (string, error)
+	// IsJWTValid evaluates a given JWT and returns a true/false if the JWT is valid (i.e. belongs to us and current) or not.
+	IsJWTValid(jwt string) (bool, error)
+	// VerifyJWT verifies an externally-issued JWT against the service's configured JWKS, returning
+	// its claims when opts are satisfied.
+	VerifyJWT(token string, opts secret.VerifyOptions) (secret.Claims, error)
+}
+
+// Ensure SecureProvider satisfies SecretProvider at compile time.
+var _ SecretProvider = (*secret.SecureProvider)(nil)
@@ -0,0 +1,272 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package secret
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-secrets/v3/secrets"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// BackendTypeVault identifies the backend wrapping the existing secrets.SecretClient (HashiCorp Vault)
+	BackendTypeVault = "vault"
+	// BackendTypeFile identifies the file-based backend used for local/dev deployments
+	BackendTypeFile = "file"
+
+	defaultBackendId = "default"
+)
+
+// SecretBackend is the contract implemented by every secret store backend that can be registered
+// with a BackendRegistry. Implementations wrap the backend-specific client/SDK so that
+// SecureProvider can route calls to the correct backend without knowing its concrete type.
+type SecretBackend interface {
+	// Get retrieves the secrets stored at secretName. If no keys are provided all keys are returned.
+	Get(secretName string, keys ...string) (map[string]string, error)
+	// Store writes the given secrets to secretName, merging with any secrets already present.
+	Store(secretName string, secrets map[string]string) error
+	// Delete removes the secret stored at secretName.
+	Delete(secretName string) error
+	// List returns the names of all secrets known to the backend.
+	List() ([]string, error)
+	// GenerateToken creates a backend-specific access token (e.g. a Consul ACL token) for serviceKey.
+	GenerateToken(serviceKey string) (string, error)
+}
+
+// BackendConfig describes a single backend instance to be instantiated by a BackendRegistry.
+// Config is backend-type specific, e.g. Vault's client configuration or the root directory
+// for a file-based backend.
+type BackendConfig struct {
+	Id     string
+	Type   string
+	Config map[string]interface{}
+}
+
+// BackendRegistryConfig is the JSON/YAML document describing the set of backends a service
+// should register and how secret names are routed to them. Bindings maps an exact secretName to
+// the Id of the backend that should serve it; binding keys are not matched as prefixes. Secret
+// names not matching any binding and not carrying a recognized scheme prefix ("vault://" or
+// "file://") fall through to the backend identified by DefaultBackendId.
+type BackendRegistryConfig struct {
+	DefaultBackendId string
+	Backends         []BackendConfig
+	Bindings         map[string]string
+}
+
+// BackendRegistry resolves a secretName to the SecretBackend that should serve it, either by an
+// explicit scheme prefix (e.g. "vault://team/db"), an entry in the configured binding table, or
+// the registry's default backend.
+type BackendRegistry struct {
+	backends         map[string]SecretBackend
+	bindings         map[string]string
+	defaultBackendId string
+	mutex            sync.RWMutex
+}
+
+// LoadBackendRegistryConfig reads and unmarshals a BackendRegistryConfig from a JSON or YAML file,
+// selecting the format based on the file extension.
+func LoadBackendRegistryConfig(configFile string) (*BackendRegistryConfig, error) {
+	contents, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backend registry config file '%s': %w", configFile, err)
+	}
+
+	config := &BackendRegistryConfig{}
+
+	switch strings.ToLower(strings.TrimPrefix(fileExt(configFile), ".")) {
+	case "yaml", "yml":
+		if err := yaml.Unmarshal(contents, config); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal backend registry config as YAML: %w", err)
+		}
+	default:
+		if err := json.Unmarshal(contents, config); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal backend registry config as JSON: %w", err)
+		}
+	}
+
+	return config, nil
+}
+
+func fileExt(path string) string {
+	if index := strings.LastIndex(path, "."); index != -1 {
+		return path[index:]
+	}
+	return ""
+}
+
+// NewBackendRegistry creates a BackendRegistry from config, instantiating each declared backend.
+// vaultClient is used to back any BackendTypeVault entries so the registry can reuse the
+// secrets.SecretClient the provider already established.
+func NewBackendRegistry(config BackendRegistryConfig, vaultClient secrets.SecretClient, lc logger.LoggingClient) (*BackendRegistry, error) {
+	registry := &BackendRegistry{
+		backends:         make(map[string]SecretBackend),
+		bindings:         config.Bindings,
+		defaultBackendId: config.DefaultBackendId,
+	}
+
+	if registry.defaultBackendId == "" {
+		registry.defaultBackendId = defaultBackendId
+	}
+
+	for _, backendConfig := range config.Backends {
+		backend, err := newBackend(backendConfig, vaultClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create backend '%s': %w", backendConfig.Id, err)
+		}
+
+		registry.backends[backendConfig.Id] = backend
+		lc.Infof("Registered secret backend '%s' of type '%s'", backendConfig.Id, backendConfig.Type)
+	}
+
+	return registry, nil
+}
+
+func newBackend(config BackendConfig, vaultClient secrets.SecretClient) (SecretBackend, error) {
+	switch config.Type {
+	case BackendTypeVault:
+		if vaultClient == nil {
+			return nil, fmt.Errorf("vault backend '%s' requires a configured secret store client", config.Id)
+		}
+		return &vaultBackend{client: vaultClient}, nil
+
+	case BackendTypeFile:
+		rootDir, ok := config.Config["rootDir"].(string)
+		if !ok || rootDir == "" {
+			return nil, fmt.Errorf("file backend '%s' requires a 'rootDir' config entry", config.Id)
+		}
+		return newFileBackend(rootDir)
+
+	default:
+		return nil, fmt.Errorf("unknown backend type '%s'", config.Type)
+	}
+}
+
+// schemePrefixes maps a secret name's "scheme://" prefix to the backend Id that owns it. Only
+// prefixes for backend types newBackend can actually instantiate are listed here; a secret
+// routed by an unlisted prefix falls through to the binding table/default backend instead of
+// always failing to resolve.
+var schemePrefixes = map[string]string{
+	"vault://": BackendTypeVault,
+	"file://":  BackendTypeFile,
+}
+
+// For resolves secretName to the backend that should serve it. An explicit binding for secretName
+// takes precedence over a scheme prefix match; with neither, it falls back to the registry's
+// default backend.
+func (r *BackendRegistry) For(secretName string) (SecretBackend, string, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	backendId := r.defaultBackendId
+
+	for prefix, id := range schemePrefixes {
+		if strings.HasPrefix(secretName, prefix) {
+			backendId = id
+			break
+		}
+	}
+
+	if bound, ok := r.bindings[secretName]; ok {
+		backendId = bound
+	}
+
+	backend, ok := r.backends[backendId]
+	if !ok {
+		return nil, "", fmt.Errorf("no secret backend registered with id '%s' for secretName '%s'", backendId, secretName)
+	}
+
+	return backend, backendId, nil
+}
+
+// Default returns the registry's default backend, i.e. the one secretName-less operations like
+// Consul token generation should use. It is a distinct accessor from For so that callers needing
+// the default backend never risk colliding with a secretName a Bindings entry happens to route
+// the same way For's defaultBackendId sentinel would.
+func (r *BackendRegistry) Default() (SecretBackend, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	backend, ok := r.backends[r.defaultBackendId]
+	if !ok {
+		return nil, fmt.Errorf("no default secret backend registered with id '%s'", r.defaultBackendId)
+	}
+
+	return backend, nil
+}
+
+// ListAll returns the secret names known across every backend registered with r.
+func (r *BackendRegistry) ListAll() ([]string, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var all []string
+	for id, backend := range r.backends {
+		names, err := backend.List()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list secrets from backend '%s': %w", id, err)
+		}
+		all = append(all, names...)
+	}
+
+	return all, nil
+}
+
+// TrimSecretName strips a recognized "scheme://" prefix from secretName before it is passed on
+// to the resolved backend, which deals only in backend-local names.
+func TrimSecretName(secretName string) string {
+	for prefix := range schemePrefixes {
+		if strings.HasPrefix(secretName, prefix) {
+			return strings.TrimPrefix(secretName, prefix)
+		}
+	}
+	return secretName
+}
+
+// vaultBackend adapts the existing secrets.SecretClient to the SecretBackend interface,
+// preserving the provider's current behavior when no other backend is configured for a secret.
+type vaultBackend struct {
+	client secrets.SecretClient
+}
+
+func (b *vaultBackend) Get(secretName string, keys ...string) (map[string]string, error) {
+	return b.client.GetSecret(secretName, keys...)
+}
+
+func (b *vaultBackend) Store(secretName string, secrets map[string]string) error {
+	return b.client.StoreSecret(secretName, secrets)
+}
+
+func (b *vaultBackend) Delete(secretName string) error {
+	deleter, ok := b.client.(secretDeleter)
+	if !ok {
+		return fmt.Errorf("configured secret store client does not support deletion")
+	}
+	return deleter.DeleteSecret(secretName)
+}
+
+func (b *vaultBackend) List() ([]string, error) {
+	return b.client.GetSecretNames()
+}
+
+func (b *vaultBackend) GenerateToken(serviceKey string) (string, error) {
+	return b.client.GenerateConsulToken(serviceKey)
+}
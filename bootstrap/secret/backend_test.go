@@ -0,0 +1,106 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package secret
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileBackend_StoreGetListDelete(t *testing.T) {
+	backend, err := newFileBackend(t.TempDir())
+	require.NoError(t, err)
+
+	// nested secretName, the shape the request calls out (e.g. "team/db")
+	err = backend.Store("team/db", map[string]string{"username": "alice", "password": "s3cr3t"})
+	require.NoError(t, err)
+
+	secrets, err := backend.Get("team/db")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", secrets["username"])
+	assert.Equal(t, "s3cr3t", secrets["password"])
+
+	names, err := backend.List()
+	require.NoError(t, err)
+	assert.Contains(t, names, "db")
+
+	require.NoError(t, backend.Delete("team/db"))
+
+	secrets, err = backend.Get("team/db")
+	require.NoError(t, err)
+	assert.Empty(t, secrets)
+}
+
+func TestFileBackend_PathTraversalRejected(t *testing.T) {
+	backend, err := newFileBackend(t.TempDir())
+	require.NoError(t, err)
+
+	err = backend.Store("../escape", map[string]string{"key": "value"})
+	assert.Error(t, err)
+
+	_, err = backend.Get("../../etc/passwd")
+	assert.Error(t, err)
+
+	err = backend.Delete("/etc/passwd")
+	assert.Error(t, err)
+}
+
+func TestBackendRegistry_SchemePrefixRouting(t *testing.T) {
+	registryConfig := BackendRegistryConfig{
+		DefaultBackendId: BackendTypeFile,
+		Backends: []BackendConfig{
+			{
+				Id:     BackendTypeFile,
+				Type:   BackendTypeFile,
+				Config: map[string]interface{}{"rootDir": t.TempDir()},
+			},
+		},
+	}
+
+	registry, err := NewBackendRegistry(registryConfig, nil, logger.NewMockClient())
+	require.NoError(t, err)
+
+	backend, id, err := registry.For("file://team/db")
+	require.NoError(t, err)
+	assert.Equal(t, BackendTypeFile, id)
+	assert.NotNil(t, backend)
+
+	assert.Equal(t, "team/db", TrimSecretName("file://team/db"))
+}
+
+func TestBackendRegistry_UnknownPrefixFallsThroughToDefault(t *testing.T) {
+	registryConfig := BackendRegistryConfig{
+		DefaultBackendId: BackendTypeFile,
+		Backends: []BackendConfig{
+			{
+				Id:     BackendTypeFile,
+				Type:   BackendTypeFile,
+				Config: map[string]interface{}{"rootDir": t.TempDir()},
+			},
+		},
+	}
+
+	registry, err := NewBackendRegistry(registryConfig, nil, logger.NewMockClient())
+	require.NoError(t, err)
+
+	// "aws-ssm://" is not a routable prefix (no backend type implements it), so it should fall
+	// through to the default backend instead of always failing to resolve.
+	_, id, err := registry.For("aws-ssm://team/db")
+	require.NoError(t, err)
+	assert.Equal(t, BackendTypeFile, id)
+}
@@ -0,0 +1,199 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package secret
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// fileBackend is a SecretBackend that stores each secretName's key/value pairs as its own JSON
+// file under rootDir. It exists so the backend registry can be exercised without a running
+// secret store, e.g. for local development or single-node deployments.
+type fileBackend struct {
+	rootDir string
+	mutex   sync.Mutex
+}
+
+func newFileBackend(rootDir string) (*fileBackend, error) {
+	if err := os.MkdirAll(rootDir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create file backend root directory '%s': %w", rootDir, err)
+	}
+
+	return &fileBackend{rootDir: rootDir}, nil
+}
+
+// pathFor resolves secretName to a file path under rootDir, rejecting any name that would
+// escape rootDir (e.g. via "../" segments or an absolute path).
+func (b *fileBackend) pathFor(secretName string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(secretName))
+	if filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid secretName '%s'", secretName)
+	}
+
+	full := filepath.Join(b.rootDir, cleaned+".json")
+
+	rel, err := filepath.Rel(b.rootDir, full)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("secretName '%s' escapes the backend root directory", secretName)
+	}
+
+	return full, nil
+}
+
+func (b *fileBackend) Get(secretName string, keys ...string) (map[string]string, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	stored, err := b.read(secretName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return stored, nil
+	}
+
+	result := make(map[string]string, len(keys))
+	for _, key := range keys {
+		value, found := stored[key]
+		if !found {
+			return nil, fmt.Errorf("key '%s' not found for secretName '%s'", key, secretName)
+		}
+		result[key] = value
+	}
+
+	return result, nil
+}
+
+func (b *fileBackend) Store(secretName string, secrets map[string]string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	stored, err := b.read(secretName)
+	if err != nil {
+		return err
+	}
+	if stored == nil {
+		stored = make(map[string]string)
+	}
+
+	for key, value := range secrets {
+		stored[key] = value
+	}
+
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("failed to marshal secrets for secretName '%s': %w", secretName, err)
+	}
+
+	path, err := b.pathFor(secretName)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("failed to create directory for secretName '%s': %w", secretName, err)
+	}
+
+	if err := os.WriteFile(path, data, 0640); err != nil {
+		return fmt.Errorf("failed to write secrets for secretName '%s': %w", secretName, err)
+	}
+
+	return nil
+}
+
+func (b *fileBackend) Delete(secretName string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	path, err := b.pathFor(secretName)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no secret found for secretName '%s'", secretName)
+		}
+		return fmt.Errorf("failed to delete secrets for secretName '%s': %w", secretName, err)
+	}
+
+	return nil
+}
+
+func (b *fileBackend) List() ([]string, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	var names []string
+	err := filepath.WalkDir(b.rootDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(b.rootDir, path)
+		if err != nil {
+			return err
+		}
+
+		secretName := filepath.ToSlash(strings.TrimSuffix(rel, ".json"))
+		names = append(names, secretName)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets in '%s': %w", b.rootDir, err)
+	}
+
+	return names, nil
+}
+
+// GenerateToken produces a random token local to this backend. The file backend has no notion
+// of a Consul ACL system, so this is only suitable for local development use cases.
+func (b *fileBackend) GenerateToken(_ string) (string, error) {
+	return uuid.NewString(), nil
+}
+
+func (b *fileBackend) read(secretName string) (map[string]string, error) {
+	path, err := b.pathFor(secretName)
+	if err != nil {
+		return nil, err
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read secrets for secretName '%s': %w", secretName, err)
+	}
+
+	stored := make(map[string]string)
+	if err := json.Unmarshal(contents, &stored); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal secrets for secretName '%s': %w", secretName, err)
+	}
+
+	return stored, nil
+}
@@ -0,0 +1,486 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package secret
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+)
+
+// Claims holds the decoded payload of a verified JWT.
+type Claims map[string]interface{}
+
+// StringClaim returns the named claim as a string, if present and of string type.
+func (c Claims) StringClaim(name string) (string, bool) {
+	value, ok := c[name].(string)
+	return value, ok
+}
+
+// VerifyOptions controls how VerifyJWT validates a token beyond signature and time-based claims.
+type VerifyOptions struct {
+	// Issuer, if set, must match the token's "iss" claim.
+	Issuer string
+	// Audience, if set, must appear in the token's "aud" claim (string or []string).
+	Audience string
+	// ClockSkew is the leeway allowed when validating "exp" and "nbf". Defaults to zero.
+	ClockSkew time.Duration
+}
+
+// JWKSOptions configures the key.Manager/key.Repo pair backing SecureProvider.VerifyJWT.
+type JWKSOptions struct {
+	// URL is the JWKS document endpoint, e.g. the secret store's identity token issuer endpoint.
+	URL string
+	// RefreshInterval is how often the key set is proactively refreshed. Defaults to one hour.
+	RefreshInterval time.Duration
+	// GraceWindow is how long a rotated-out key remains valid for verification after a refresh
+	// stops returning it, so in-flight tokens signed by the old key are not rejected.
+	GraceWindow time.Duration
+	// HTTPClient is used to fetch the JWKS document. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// jwksKey is a single parsed entry from a JWKS document.
+type jwksKey struct {
+	kid       string
+	alg       string
+	publicKey crypto.PublicKey
+	expiresAt time.Time // zero until the key is rotated out, then end of its grace window
+}
+
+// keyRepo holds the set of keys currently known to be valid, keyed by "kid", and honors a grace
+// window so a key rotated out of the JWKS document is still accepted for a while afterward.
+type keyRepo struct {
+	mutex       sync.RWMutex
+	keys        map[string]*jwksKey
+	graceWindow time.Duration
+}
+
+func newKeyRepo(graceWindow time.Duration) *keyRepo {
+	return &keyRepo{
+		keys:        make(map[string]*jwksKey),
+		graceWindow: graceWindow,
+	}
+}
+
+// key returns the key for kid, if it is currently present or still inside its grace window.
+func (r *keyRepo) key(kid string) (*jwksKey, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	key, ok := r.keys[kid]
+	if !ok {
+		return nil, false
+	}
+
+	if !key.expiresAt.IsZero() && time.Now().After(key.expiresAt) {
+		return nil, false
+	}
+
+	return key, true
+}
+
+// replace installs a freshly-fetched key set, keeping any previously-known key that is no longer
+// present for graceWindow so newly-rotated signers don't cause verification failures mid-rotation.
+func (r *keyRepo) replace(fetched []*jwksKey) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	next := make(map[string]*jwksKey, len(fetched))
+	for _, key := range fetched {
+		next[key.kid] = key
+	}
+
+	if r.graceWindow > 0 {
+		expiresAt := time.Now().Add(r.graceWindow)
+		for kid, key := range r.keys {
+			if _, stillPresent := next[kid]; stillPresent {
+				continue
+			}
+			if key.expiresAt.IsZero() {
+				rotatedOut := *key
+				rotatedOut.expiresAt = expiresAt
+				next[kid] = &rotatedOut
+			} else if time.Now().Before(key.expiresAt) {
+				// already rotated out by an earlier refresh: keep its original grace deadline
+				next[kid] = key
+			}
+		}
+	}
+
+	r.keys = next
+}
+
+// keyManager fetches and periodically refreshes a JWKS document, exposing keys by "kid" through
+// its keyRepo. A refresh triggered by a "kid" cache miss is single-flight guarded so concurrent
+// verifications of tokens signed by a newly-rotated key only trigger one HTTP round trip.
+type keyManager struct {
+	repo       *keyRepo
+	url        string
+	httpClient *http.Client
+	lc         logger.LoggingClient
+
+	refreshMutex sync.Mutex
+	refreshing   bool
+	refreshDone  chan struct{}
+}
+
+func newKeyManager(opts JWKSOptions, lc logger.LoggingClient) *keyManager {
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &keyManager{
+		repo:       newKeyRepo(opts.GraceWindow),
+		url:        opts.URL,
+		httpClient: httpClient,
+		lc:         lc,
+	}
+}
+
+// start fetches the key set once synchronously, then refreshes it every interval until ctx is
+// done.
+func (m *keyManager) start(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	if err := m.refresh(); err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := m.refresh(); err != nil {
+					m.lc.Errorf("failed to refresh JWKS key set from '%s': %v", m.url, err)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// keyForKid returns the key for kid, triggering a single-flight refresh on a cache miss in case
+// the key set has rotated since the last refresh.
+func (m *keyManager) keyForKid(kid string) (*jwksKey, error) {
+	if key, ok := m.repo.key(kid); ok {
+		return key, nil
+	}
+
+	if err := m.refreshSingleFlight(); err != nil {
+		return nil, err
+	}
+
+	key, ok := m.repo.key(kid)
+	if !ok {
+		return nil, fmt.Errorf("no key found for kid '%s'", kid)
+	}
+
+	return key, nil
+}
+
+// refreshSingleFlight ensures only one goroutine performs the HTTP refresh at a time; concurrent
+// callers wait for that in-flight refresh to finish rather than each issuing their own request.
+func (m *keyManager) refreshSingleFlight() error {
+	m.refreshMutex.Lock()
+	if m.refreshing {
+		done := m.refreshDone
+		m.refreshMutex.Unlock()
+		<-done
+		return nil
+	}
+
+	m.refreshing = true
+	done := make(chan struct{})
+	m.refreshDone = done
+	m.refreshMutex.Unlock()
+
+	err := m.refresh()
+
+	m.refreshMutex.Lock()
+	m.refreshing = false
+	m.refreshMutex.Unlock()
+	close(done)
+
+	return err
+}
+
+func (m *keyManager) refresh() error {
+	resp, err := m.httpClient.Get(m.url)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS document from '%s': %w", m.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch JWKS document from '%s': status %d", m.url, resp.StatusCode)
+	}
+
+	var document struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Alg string `json:"alg"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+			Crv string `json:"crv"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		} `json:"keys"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&document); err != nil {
+		return fmt.Errorf("failed to decode JWKS document from '%s': %w", m.url, err)
+	}
+
+	keys := make([]*jwksKey, 0, len(document.Keys))
+	for _, entry := range document.Keys {
+		publicKey, err := parseJWKSKey(entry.Kty, entry.Crv, entry.N, entry.E, entry.X, entry.Y)
+		if err != nil {
+			m.lc.Warnf("skipping JWKS key '%s': %v", entry.Kid, err)
+			continue
+		}
+
+		keys = append(keys, &jwksKey{kid: entry.Kid, alg: entry.Alg, publicKey: publicKey})
+	}
+
+	m.repo.replace(keys)
+	return nil
+}
+
+func parseJWKSKey(kty, crv, n, e, x, y string) (crypto.PublicKey, error) {
+	switch kty {
+	case "RSA":
+		nBytes, err := base64.RawURLEncoding.DecodeString(n)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA modulus: %w", err)
+		}
+		eBytes, err := base64.RawURLEncoding.DecodeString(e)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA exponent: %w", err)
+		}
+
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(nBytes),
+			E: int(new(big.Int).SetBytes(eBytes).Int64()),
+		}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported EC curve '%s'", crv)
+		}
+
+		xBytes, err := base64.RawURLEncoding.DecodeString(x)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC x coordinate: %w", err)
+		}
+		yBytes, err := base64.RawURLEncoding.DecodeString(y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC y coordinate: %w", err)
+		}
+
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(xBytes),
+			Y:     new(big.Int).SetBytes(yBytes),
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported key type '%s'", kty)
+	}
+}
+
+// ConfigureJWKS initializes the offline JWT verifier backing VerifyJWT. It fetches the JWKS
+// document from opts.URL once synchronously, then keeps refreshing it in the background for the
+// lifetime of the provider's context.
+func (p *SecureProvider) ConfigureJWKS(opts JWKSOptions) error {
+	manager := newKeyManager(opts, p.lc)
+	if err := manager.start(p.ctx, opts.RefreshInterval); err != nil {
+		return err
+	}
+
+	p.keyManager = manager
+	return nil
+}
+
+// VerifyJWT validates token entirely offline against the JWKS-derived key set: signature, "exp",
+// "nbf", and (when configured in opts) "iss" and "aud". Unlike IsJWTValid, this never makes a
+// network round trip to the secret store, so it is suitable for per-request use in HTTP
+// middleware. ConfigureJWKS must be called once before VerifyJWT is used.
+func (p *SecureProvider) VerifyJWT(token string, opts VerifyOptions) (Claims, error) {
+	if p.keyManager == nil {
+		return nil, errors.New("can't verify JWT. JWKS verifier is not configured; call ConfigureJWKS first")
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed JWT: expected three dot-separated segments")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	headerBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("malformed JWT header: %w", err)
+	}
+	if err := json.Unmarshal(headerBytes, &header); err != nil {
+		return nil, fmt.Errorf("malformed JWT header: %w", err)
+	}
+
+	claimsBytes, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("malformed JWT claims: %w", err)
+	}
+	claims := make(Claims)
+	if err := json.Unmarshal(claimsBytes, &claims); err != nil {
+		return nil, fmt.Errorf("malformed JWT claims: %w", err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed JWT signature: %w", err)
+	}
+
+	key, err := p.keyManager.keyForKid(header.Kid)
+	if err != nil {
+		return nil, fmt.Errorf("unable to verify JWT: %w", err)
+	}
+
+	signingInput := parts[0] + "." + parts[1]
+	if err := verifySignature(header.Alg, key.publicKey, []byte(signingInput), signature); err != nil {
+		return nil, fmt.Errorf("JWT signature verification failed: %w", err)
+	}
+
+	if err := verifyTimeClaims(claims, opts.ClockSkew); err != nil {
+		return nil, err
+	}
+
+	if opts.Issuer != "" {
+		if iss, ok := claims.StringClaim("iss"); !ok || iss != opts.Issuer {
+			return nil, fmt.Errorf("JWT issuer '%s' does not match expected issuer '%s'", iss, opts.Issuer)
+		}
+	}
+
+	if opts.Audience != "" && !claimsContainAudience(claims, opts.Audience) {
+		return nil, fmt.Errorf("JWT audience does not contain expected audience '%s'", opts.Audience)
+	}
+
+	return claims, nil
+}
+
+func verifySignature(alg string, publicKey crypto.PublicKey, signingInput, signature []byte) error {
+	digest := sha256.Sum256(signingInput)
+
+	switch alg {
+	case "RS256":
+		rsaKey, ok := publicKey.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key is not an RSA public key for alg '%s'", alg)
+		}
+		return rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, digest[:], signature)
+
+	case "ES256":
+		ecKey, ok := publicKey.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key is not an EC public key for alg '%s'", alg)
+		}
+		if len(signature) != 64 {
+			return errors.New("invalid ES256 signature length")
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(ecKey, digest[:], r, s) {
+			return errors.New("invalid signature")
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported JWT signing algorithm '%s'", alg)
+	}
+}
+
+func verifyTimeClaims(claims Claims, skew time.Duration) error {
+	now := time.Now()
+
+	exp, ok := numericClaim(claims, "exp")
+	if !ok {
+		return errors.New("JWT is missing required 'exp' claim")
+	}
+	if now.After(time.Unix(exp, 0).Add(skew)) {
+		return errors.New("JWT is expired")
+	}
+
+	if nbf, ok := numericClaim(claims, "nbf"); ok {
+		if now.Before(time.Unix(nbf, 0).Add(-skew)) {
+			return errors.New("JWT is not yet valid")
+		}
+	}
+
+	return nil
+}
+
+func numericClaim(claims Claims, name string) (int64, bool) {
+	value, ok := claims[name].(float64)
+	if !ok {
+		return 0, false
+	}
+	return int64(value), true
+}
+
+func claimsContainAudience(claims Claims, audience string) bool {
+	switch aud := claims["aud"].(type) {
+	case string:
+		return aud == audience
+	case []interface{}:
+		for _, entry := range aud {
+			if value, ok := entry.(string); ok && value == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
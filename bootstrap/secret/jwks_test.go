@@ -0,0 +1,184 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package secret
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type jwksTestServer struct {
+	*httptest.Server
+	keys []rsaJWK
+}
+
+type rsaJWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+func newJWKSTestServer(t *testing.T) *jwksTestServer {
+	server := &jwksTestServer{}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": server.keys})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func rsaJWKFor(kid string, key *rsa.PrivateKey) rsaJWK {
+	eBytes := big.NewInt(int64(key.PublicKey.E)).Bytes()
+	return rsaJWK{
+		Kty: "RSA",
+		Kid: kid,
+		Alg: "RS256",
+		N:   base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(eBytes),
+	}
+}
+
+func mintRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	header := map[string]interface{}{"alg": "RS256", "kid": kid}
+	headerJSON, err := json.Marshal(header)
+	require.NoError(t, err)
+	claimsJSON, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+
+	signature, err := signRS256(key, signingInput)
+	require.NoError(t, err)
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func signRS256(key *rsa.PrivateKey, signingInput string) ([]byte, error) {
+	digest := sha256.Sum256([]byte(signingInput))
+	return rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+}
+
+func TestVerifyJWT_RS256Success(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	server := newJWKSTestServer(t)
+	server.keys = []rsaJWK{rsaJWKFor("k1", key)}
+
+	provider := &SecureProvider{lc: logger.NewMockClient(), ctx: context.Background()}
+	require.NoError(t, provider.ConfigureJWKS(JWKSOptions{URL: server.URL, RefreshInterval: time.Hour}))
+
+	token := mintRS256(t, key, "k1", map[string]interface{}{
+		"sub": "device-service",
+		"iss": "edgex",
+		"aud": "edgex",
+		"exp": float64(time.Now().Add(time.Hour).Unix()),
+	})
+
+	claims, err := provider.VerifyJWT(token, VerifyOptions{Issuer: "edgex", Audience: "edgex"})
+	require.NoError(t, err)
+	subject, ok := claims.StringClaim("sub")
+	require.True(t, ok)
+	assert.Equal(t, "device-service", subject)
+}
+
+func TestVerifyJWT_MissingExpIsRejected(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	server := newJWKSTestServer(t)
+	server.keys = []rsaJWK{rsaJWKFor("k1", key)}
+
+	provider := &SecureProvider{lc: logger.NewMockClient(), ctx: context.Background()}
+	require.NoError(t, provider.ConfigureJWKS(JWKSOptions{URL: server.URL, RefreshInterval: time.Hour}))
+
+	token := mintRS256(t, key, "k1", map[string]interface{}{"sub": "device-service"})
+
+	_, err = provider.VerifyJWT(token, VerifyOptions{})
+	assert.Error(t, err)
+}
+
+func TestVerifyJWT_ExpiredIsRejected(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	server := newJWKSTestServer(t)
+	server.keys = []rsaJWK{rsaJWKFor("k1", key)}
+
+	provider := &SecureProvider{lc: logger.NewMockClient(), ctx: context.Background()}
+	require.NoError(t, provider.ConfigureJWKS(JWKSOptions{URL: server.URL, RefreshInterval: time.Hour}))
+
+	token := mintRS256(t, key, "k1", map[string]interface{}{
+		"exp": float64(time.Now().Add(-time.Hour).Unix()),
+	})
+
+	_, err = provider.VerifyJWT(token, VerifyOptions{})
+	assert.Error(t, err)
+}
+
+func TestVerifyJWT_AudienceMismatchIsRejected(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	server := newJWKSTestServer(t)
+	server.keys = []rsaJWK{rsaJWKFor("k1", key)}
+
+	provider := &SecureProvider{lc: logger.NewMockClient(), ctx: context.Background()}
+	require.NoError(t, provider.ConfigureJWKS(JWKSOptions{URL: server.URL, RefreshInterval: time.Hour}))
+
+	token := mintRS256(t, key, "k1", map[string]interface{}{
+		"aud": "some-other-service",
+		"exp": float64(time.Now().Add(time.Hour).Unix()),
+	})
+
+	_, err = provider.VerifyJWT(token, VerifyOptions{Audience: "device-service"})
+	assert.Error(t, err)
+}
+
+func TestKeyRepo_GraceWindowAllowsRotatedKeyBriefly(t *testing.T) {
+	repo := newKeyRepo(50 * time.Millisecond)
+
+	oldKey := &jwksKey{kid: "old"}
+	repo.replace([]*jwksKey{oldKey})
+
+	// Rotate: a refresh that no longer returns "old" should keep it valid for the grace window.
+	newKey := &jwksKey{kid: "new"}
+	repo.replace([]*jwksKey{newKey})
+
+	_, ok := repo.key("old")
+	assert.True(t, ok, "rotated-out key should still be valid inside the grace window")
+
+	time.Sleep(75 * time.Millisecond)
+
+	_, ok = repo.key("old")
+	assert.False(t, ok, "rotated-out key should expire once the grace window elapses")
+}
@@ -38,6 +38,13 @@ import (
 	"github.com/edgexfoundry/go-mod-secrets/v3/secrets"
 )
 
+// secretDeleter is implemented by secrets.SecretClient implementations that support removing a
+// secret outright. It is asserted for rather than added to the secrets.SecretClient interface so
+// that SecureProvider still works with clients that only support the baseline operations.
+type secretDeleter interface {
+	DeleteSecret(secretName string) error
+}
+
 const (
 	TokenTypeConsul      = "consul"
 	AccessTokenAuthError = "HTTP response with status code 403"
@@ -63,6 +70,16 @@ type SecureProvider struct {
 	securitySecretsStored         gometrics.Counter
 	securityConsulTokensRequested gometrics.Counter
 	securityConsulTokenDuration   gometrics.Timer
+	// backendRegistry routes Get/Store/List/GenerateToken calls to a secretName-specific
+	// SecretBackend. It is nil until ConfigureBackendRegistry has been called; while it is nil
+	// the provider uses secretClient directly, preserving prior behavior.
+	backendRegistry *BackendRegistry
+	// keyManager backs VerifyJWT with an offline, JWKS-derived key set. It is nil unless
+	// ConfigureJWKS has been called, in which case IsJWTValid remains available as a fallback.
+	keyManager *keyManager
+	// templatedCache holds GetSecretTemplated results, keyed by a hash of their TemplateContext.
+	templatedCache      map[string]templatedCacheEntry
+	templatedCacheMutex sync.RWMutex
 }
 
 // NewSecureProvider creates & initializes Provider instance for secure secrets.
@@ -93,6 +110,25 @@ func (p *SecureProvider) SetClient(client secrets.SecretClient) {
 	p.secretClient = client
 }
 
+// ConfigureBackendRegistry builds the provider's BackendRegistry from the backend registry
+// config file at configFile, registering secretClient as the backend behind any BackendTypeVault
+// entries. Once configured, GetSecret/StoreSecret/ListSecretNames/GetAccessToken route each
+// secretName to the backend resolved by the registry instead of always using secretClient.
+func (p *SecureProvider) ConfigureBackendRegistry(configFile string) error {
+	registryConfig, err := LoadBackendRegistryConfig(configFile)
+	if err != nil {
+		return err
+	}
+
+	registry, err := NewBackendRegistry(*registryConfig, p.secretClient, p.lc)
+	if err != nil {
+		return err
+	}
+
+	p.backendRegistry = registry
+	return nil
+}
+
 // GetSecret retrieves secrets from a secret store.
 // secretName specifies the type or location of the secrets to retrieve.
 // keys specifies the secrets which to retrieve. If no keys are provided then all the keys associated with the
@@ -104,6 +140,21 @@ func (p *SecureProvider) GetSecret(secretName string, keys ...string) (map[strin
 		return cachedSecrets, nil
 	}
 
+	if p.backendRegistry != nil {
+		backend, _, err := p.backendRegistry.For(secretName)
+		if err != nil {
+			return nil, err
+		}
+
+		secureSecrets, err := backend.Get(TrimSecretName(secretName), keys...)
+		if err != nil {
+			return nil, err
+		}
+
+		p.updateSecretsCache(secretName, secureSecrets)
+		return secureSecrets, nil
+	}
+
 	if p.secretClient == nil {
 		return nil, errors.New("can't get secrets. Secure secret provider is not properly initialized")
 	}
@@ -175,6 +226,19 @@ func (p *SecureProvider) updateSecretsCache(secretName string, secrets map[strin
 func (p *SecureProvider) StoreSecret(secretName string, secrets map[string]string) error {
 	p.securitySecretsStored.Inc(1)
 
+	if p.backendRegistry != nil {
+		backend, _, err := p.backendRegistry.For(secretName)
+		if err != nil {
+			return err
+		}
+
+		if err := backend.Store(TrimSecretName(secretName), secrets); err != nil {
+			return err
+		}
+
+		return p.finishStoreSecret(secretName)
+	}
+
 	if p.secretClient == nil {
 		return errors.New("can't store secrets. Secure secret provider is not properly initialized")
 	}
@@ -191,6 +255,14 @@ func (p *SecureProvider) StoreSecret(secretName string, secrets map[string]strin
 		return err
 	}
 
+	return p.finishStoreSecret(secretName)
+}
+
+// finishStoreSecret runs the post-store bookkeeping shared by every backend: invoking the
+// secretName's registered callback and invalidating the secrets cache.
+func (p *SecureProvider) finishStoreSecret(secretName string) error {
+	p.invalidateTemplatedCache(secretName)
+
 	// Execute Callbacks on registered secret secretNames.
 	p.SecretUpdatedAtSecretName(secretName)
 
@@ -204,6 +276,42 @@ func (p *SecureProvider) StoreSecret(secretName string, secrets map[string]strin
 	return nil
 }
 
+// DeleteSecret removes the secret stored at secretName, invalidates the cache and fires any
+// registered callback for secretName, same as StoreSecret does for a write.
+func (p *SecureProvider) DeleteSecret(secretName string) error {
+	if p.backendRegistry != nil {
+		backend, _, err := p.backendRegistry.For(secretName)
+		if err != nil {
+			return err
+		}
+
+		if err := backend.Delete(TrimSecretName(secretName)); err != nil {
+			return err
+		}
+
+		return p.finishStoreSecret(secretName)
+	}
+
+	deleter, ok := p.secretClient.(secretDeleter)
+	if !ok {
+		return fmt.Errorf("can't delete secrets. Configured secret store client does not support deletion")
+	}
+
+	err := deleter.DeleteSecret(secretName)
+
+	retry, err := p.reloadTokenOnAuthError(err)
+	if retry {
+		// Retry with potential new token
+		err = deleter.DeleteSecret(secretName)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return p.finishStoreSecret(secretName)
+}
+
 func (p *SecureProvider) reloadTokenOnAuthError(err error) (bool, error) {
 	if err == nil {
 		return false, nil
@@ -246,6 +354,14 @@ func (p *SecureProvider) GetAccessToken(tokenType string, serviceKey string) (st
 
 	switch tokenType {
 	case TokenTypeConsul:
+		if p.backendRegistry != nil {
+			backend, err := p.backendRegistry.Default()
+			if err != nil {
+				return "", err
+			}
+			return backend.GenerateToken(serviceKey)
+		}
+
 		token, err := p.secretClient.GenerateConsulToken(serviceKey)
 
 		retry, err := p.reloadTokenOnAuthError(err)
@@ -391,6 +507,10 @@ func (p *SecureProvider) HasSecret(secretName string) (bool, error) {
 // ListSecretSecretNames returns a list of secretNames for the current service from an insecure/secure secret store.
 func (p *SecureProvider) ListSecretNames() ([]string, error) {
 
+	if p.backendRegistry != nil {
+		return p.backendRegistry.ListAll()
+	}
+
 	if p.secretClient == nil {
 		return nil, errors.New("can't get secret secretNames. Secure secret provider is not properly initialized")
 	}
@@ -0,0 +1,187 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package secret
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"text/template"
+	"time"
+)
+
+// defaultTemplatedSecretTTL is used when a TemplateContext does not set its own TTL.
+const defaultTemplatedSecretTTL = time.Minute
+
+// TemplateContext carries the authorization context a secret template may reference in addition
+// to the secret's own key/value pairs: the caller's verified JWT claims and, when the caller
+// authenticated with a client certificate, that certificate's leaf.
+type TemplateContext struct {
+	// Claims are the caller's verified JWT claims, exposed to templates as ".Claims.<name>".
+	Claims Claims
+	// AuthorizationCrt is the caller's leaf client certificate, if any, exposed to templates as
+	// ".AuthorizationCrt.<field>", e.g. ".AuthorizationCrt.Subject".
+	AuthorizationCrt *x509.Certificate
+	// TTL overrides how long the rendered result is cached. Defaults to defaultTemplatedSecretTTL.
+	TTL time.Duration
+}
+
+var templateFuncs = template.FuncMap{
+	"base64": func(s string) string {
+		return base64.StdEncoding.EncodeToString([]byte(s))
+	},
+	"hex": func(s string) string {
+		return hex.EncodeToString([]byte(s))
+	},
+	"hmac": func(key, message string) string {
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write([]byte(message))
+		return hex.EncodeToString(mac.Sum(nil))
+	},
+}
+
+type templatedCacheEntry struct {
+	secretName string
+	values     map[string]string
+	expiresAt  time.Time
+}
+
+// GetSecretTemplated renders tmplText against the secret at secretName's key/value pairs plus ctx,
+// consulting the rendered-result cache before ever fetching the secret itself so a cached
+// rendering is servable even if the backend is temporarily unreachable. template must render to a
+// JSON object; its keys and values become the returned map. This lets a single stored secret
+// entry be specialized per caller, e.g. deriving a database username scoped to the caller's JWT
+// subject. Rendered results are cached under a key derived from secretName, tmplText and ctx until
+// ctx.TTL (or defaultTemplatedSecretTTL) elapses, or until the underlying secret is next stored.
+func (p *SecureProvider) GetSecretTemplated(secretName string, tmplText string, ctx TemplateContext) (map[string]string, error) {
+	cacheKey := secretName + ":" + hashTemplate(tmplText) + ":" + hashTemplateContext(ctx)
+
+	if cached := p.getTemplatedCache(cacheKey); cached != nil {
+		return cached, nil
+	}
+
+	secretsKV, err := p.GetSecret(secretName)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(secretName).Funcs(templateFuncs).Parse(tmplText)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse secret template for '%s': %w", secretName, err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, templateData(secretsKV, ctx)); err != nil {
+		return nil, fmt.Errorf("failed to render secret template for '%s': %w", secretName, err)
+	}
+
+	result := make(map[string]string)
+	if err := json.Unmarshal(rendered.Bytes(), &result); err != nil {
+		return nil, fmt.Errorf("secret template for '%s' did not render to a JSON object: %w", secretName, err)
+	}
+
+	ttl := ctx.TTL
+	if ttl <= 0 {
+		ttl = defaultTemplatedSecretTTL
+	}
+	p.updateTemplatedCache(secretName, cacheKey, result, ttl)
+
+	return result, nil
+}
+
+func templateData(secretsKV map[string]string, ctx TemplateContext) map[string]interface{} {
+	data := make(map[string]interface{}, len(secretsKV)+2)
+	for key, value := range secretsKV {
+		data[key] = value
+	}
+	data["Claims"] = ctx.Claims
+	data["AuthorizationCrt"] = ctx.AuthorizationCrt
+	return data
+}
+
+// hashTemplate derives a stable cache key component from the template text itself, so two
+// different templates rendered over the same secret never share a cached rendering.
+func hashTemplate(tmplText string) string {
+	sum := sha256.Sum256([]byte(tmplText))
+	return hex.EncodeToString(sum[:])
+}
+
+// hashTemplateContext derives a stable cache key component from ctx's claims and certificate so
+// two callers with different authorization contexts never share a cached rendering.
+func hashTemplateContext(ctx TemplateContext) string {
+	hasher := sha256.New()
+
+	keys := make([]string, 0, len(ctx.Claims))
+	for key := range ctx.Claims {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fmt.Fprintf(hasher, "%s=%v;", key, ctx.Claims[key])
+	}
+
+	if ctx.AuthorizationCrt != nil {
+		hasher.Write(ctx.AuthorizationCrt.Raw)
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+func (p *SecureProvider) getTemplatedCache(cacheKey string) map[string]string {
+	p.templatedCacheMutex.RLock()
+	defer p.templatedCacheMutex.RUnlock()
+
+	entry, ok := p.templatedCache[cacheKey]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil
+	}
+
+	return entry.values
+}
+
+func (p *SecureProvider) updateTemplatedCache(secretName, cacheKey string, values map[string]string, ttl time.Duration) {
+	p.templatedCacheMutex.Lock()
+	defer p.templatedCacheMutex.Unlock()
+
+	if p.templatedCache == nil {
+		p.templatedCache = make(map[string]templatedCacheEntry)
+	}
+
+	p.templatedCache[cacheKey] = templatedCacheEntry{
+		secretName: secretName,
+		values:     values,
+		expiresAt:  time.Now().Add(ttl),
+	}
+}
+
+// invalidateTemplatedCache drops every cached templated rendering derived from secretName, called
+// whenever secretName is stored so stale renderings are never served.
+func (p *SecureProvider) invalidateTemplatedCache(secretName string) {
+	p.templatedCacheMutex.Lock()
+	defer p.templatedCacheMutex.Unlock()
+
+	for cacheKey, entry := range p.templatedCache {
+		if entry.secretName == secretName {
+			delete(p.templatedCache, cacheKey)
+		}
+	}
+}
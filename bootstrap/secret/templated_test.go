@@ -0,0 +1,139 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package secret
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	gometrics "github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTemplatedTestProvider builds a SecureProvider backed by a real, temp-dir-scoped file
+// backend, so GetSecretTemplated exercises its full path through GetSecret without needing a
+// fake of the external secrets.SecretClient interface.
+func newTemplatedTestProvider(t *testing.T) *SecureProvider {
+	backend, err := newFileBackend(t.TempDir())
+	require.NoError(t, err)
+
+	registry := &BackendRegistry{
+		backends:         map[string]SecretBackend{BackendTypeFile: backend},
+		defaultBackendId: BackendTypeFile,
+	}
+
+	return &SecureProvider{
+		secretsCache:             make(map[string]map[string]string),
+		cacheMutex:               &sync.RWMutex{},
+		securitySecretsRequested: gometrics.NewCounter(),
+		securitySecretsStored:    gometrics.NewCounter(),
+		lc:                       logger.NewMockClient(),
+		backendRegistry:          registry,
+		registeredSecretCallbacks: make(map[string]func(secretName string)),
+	}
+}
+
+func TestGetSecretTemplated_RendersClaimsAndSecretData(t *testing.T) {
+	provider := newTemplatedTestProvider(t)
+	require.NoError(t, provider.StoreSecret("db", map[string]string{"password": "hunter2"}))
+
+	tmpl := `{"username": "{{.Claims.sub}}", "password": "{{.password}}"}`
+	result, err := provider.GetSecretTemplated("db", tmpl, TemplateContext{Claims: Claims{"sub": "alice"}})
+	require.NoError(t, err)
+	assert.Equal(t, "alice", result["username"])
+	assert.Equal(t, "hunter2", result["password"])
+}
+
+func TestGetSecretTemplated_TemplateFunctions(t *testing.T) {
+	provider := newTemplatedTestProvider(t)
+	require.NoError(t, provider.StoreSecret("db", map[string]string{"password": "hunter2"}))
+
+	tmpl := `{"hashed": "{{hmac .password "msg"}}", "encoded": "{{base64 .password}}"}`
+	result, err := provider.GetSecretTemplated("db", tmpl, TemplateContext{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, result["hashed"])
+	assert.Equal(t, "aHVudGVyMg==", result["encoded"])
+}
+
+func TestGetSecretTemplated_DifferentSecretsDoNotShareCache(t *testing.T) {
+	provider := newTemplatedTestProvider(t)
+	require.NoError(t, provider.StoreSecret("db", map[string]string{"value": "db-secret"}))
+	require.NoError(t, provider.StoreSecret("cache", map[string]string{"value": "cache-secret"}))
+
+	tmpl := `{"value": "{{.value}}"}`
+	ctx := TemplateContext{} // identical, empty context for both calls
+
+	dbResult, err := provider.GetSecretTemplated("db", tmpl, ctx)
+	require.NoError(t, err)
+
+	cacheResult, err := provider.GetSecretTemplated("cache", tmpl, ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, "db-secret", dbResult["value"])
+	assert.Equal(t, "cache-secret", cacheResult["value"])
+}
+
+func TestGetSecretTemplated_DifferentTemplatesDoNotShareCache(t *testing.T) {
+	provider := newTemplatedTestProvider(t)
+	require.NoError(t, provider.StoreSecret("db", map[string]string{"value": "db-secret"}))
+	ctx := TemplateContext{}
+
+	first, err := provider.GetSecretTemplated("db", `{"out": "{{.value}}"}`, ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "db-secret", first["out"])
+
+	second, err := provider.GetSecretTemplated("db", `{"out": "static"}`, ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "static", second["out"])
+}
+
+func TestGetSecretTemplated_CachesRenderedResult(t *testing.T) {
+	provider := newTemplatedTestProvider(t)
+	require.NoError(t, provider.StoreSecret("db", map[string]string{"value": "original"}))
+	ctx := TemplateContext{}
+	tmpl := `{"out": "{{.value}}"}`
+
+	first, err := provider.GetSecretTemplated("db", tmpl, ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "original", first["out"])
+
+	// Change the stored secret directly through the backend, bypassing StoreSecret so the
+	// templated-result cache is not invalidated. A cache hit should still return the original
+	// rendering rather than re-rendering against the new value.
+	backend := provider.backendRegistry.backends[BackendTypeFile]
+	require.NoError(t, backend.Store("db", map[string]string{"value": "mutated"}))
+
+	second, err := provider.GetSecretTemplated("db", tmpl, ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "original", second["out"])
+}
+
+func TestGetSecretTemplated_InvalidatedOnStore(t *testing.T) {
+	provider := newTemplatedTestProvider(t)
+	require.NoError(t, provider.StoreSecret("db", map[string]string{"value": "original"}))
+	ctx := TemplateContext{}
+	tmpl := `{"out": "{{.value}}"}`
+
+	_, err := provider.GetSecretTemplated("db", tmpl, ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, provider.StoreSecret("db", map[string]string{"value": "updated"}))
+
+	result, err := provider.GetSecretTemplated("db", tmpl, ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "updated", result["out"])
+}